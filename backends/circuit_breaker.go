@@ -0,0 +1,269 @@
+package backends
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-cache/config"
+	"github.com/prebid/prebid-cache/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+// circuitState is the classic closed/open/half-open circuit breaker state machine.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+var (
+	circuitBreakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "prebid_cache_circuit_breaker_transitions_total",
+		Help: "Count of circuit breaker state transitions, labeled by the state entered.",
+	}, []string{"backend", "state"})
+
+	circuitBreakerShortCircuits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "prebid_cache_circuit_breaker_short_circuits_total",
+		Help: "Count of Get/Put calls rejected without touching the network because the circuit was open.",
+	}, []string{"backend", "method"})
+)
+
+// CircuitBreakerBackend decorates any Backend with a rolling-window circuit breaker: once
+// failures within config.CircuitBreaker.Window cross FailureThreshold, Get/Put short-circuit
+// with a BACKEND_UNAVAILABLE error for OpenStateDuration instead of spending the caller's
+// timeout hammering a downed cluster. After OpenStateDuration it lets up to
+// HalfOpenConcurrency probe calls through to decide whether to close again.
+type CircuitBreakerBackend struct {
+	name    string
+	wrapped Backend
+	cfg     config.CircuitBreaker
+
+	mu               sync.Mutex
+	state            circuitState
+	failureTimes     []time.Time
+	openedAt         time.Time
+	halfOpenInFlight int
+}
+
+// defaultCircuitBreakerFailureThreshold, defaultCircuitBreakerWindow,
+// defaultCircuitBreakerOpenStateDuration and defaultCircuitBreakerHalfOpenConcurrency are
+// used whenever the operator leaves config.CircuitBreaker unset. Without these, a
+// zero-value HalfOpenConcurrency would wedge the breaker open forever, since it could never
+// admit a probe to test whether the backend has recovered.
+//
+// Each failure is only recorded once the underlying retryableIgniteClient has exhausted its
+// own retries (see defaultIgniteRetryMax/defaultIgniteRetryWaitMax in ignite_retry.go), so
+// consecutive failures can land tens of seconds apart. defaultCircuitBreakerWindow must
+// comfortably exceed that worst-case retry duration, or dropOlderThan prunes earlier
+// failures before FailureThreshold is ever reached and the breaker never opens.
+const (
+	defaultCircuitBreakerFailureThreshold    = 5
+	defaultCircuitBreakerWindow              = 2 * time.Minute
+	defaultCircuitBreakerOpenStateDuration   = 30 * time.Second
+	defaultCircuitBreakerHalfOpenConcurrency = 1
+)
+
+// NewCircuitBreakerBackend wraps backend with a circuit breaker configured by cfg, falling
+// back to the package defaults for any knob the operator left unset. name is used only to
+// label the Prometheus metrics (e.g. "ignite").
+func NewCircuitBreakerBackend(name string, backend Backend, cfg config.CircuitBreaker) *CircuitBreakerBackend {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultCircuitBreakerFailureThreshold
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultCircuitBreakerWindow
+	}
+	if cfg.OpenStateDuration <= 0 {
+		cfg.OpenStateDuration = defaultCircuitBreakerOpenStateDuration
+	}
+	if cfg.HalfOpenConcurrency <= 0 {
+		cfg.HalfOpenConcurrency = defaultCircuitBreakerHalfOpenConcurrency
+	}
+
+	return &CircuitBreakerBackend{
+		name:    name,
+		wrapped: backend,
+		cfg:     cfg,
+		state:   circuitClosed,
+	}
+}
+
+// Get implements the Backend interface.
+func (b *CircuitBreakerBackend) Get(ctx context.Context, key string) (string, error) {
+	if !b.allow() {
+		circuitBreakerShortCircuits.WithLabelValues(b.name, "get").Inc()
+		return "", utils.NewPBCError(utils.BACKEND_UNAVAILABLE)
+	}
+
+	val, err := b.wrapped.Get(ctx, key)
+	b.recordResult(err)
+	return val, err
+}
+
+// Put implements the Backend interface.
+func (b *CircuitBreakerBackend) Put(ctx context.Context, key string, value string, ttlSeconds int) error {
+	if !b.allow() {
+		circuitBreakerShortCircuits.WithLabelValues(b.name, "put").Inc()
+		return utils.NewPBCError(utils.BACKEND_UNAVAILABLE)
+	}
+
+	err := b.wrapped.Put(ctx, key, value, ttlSeconds)
+	b.recordResult(err)
+	return err
+}
+
+// Ping implements the Pinger interface, forwarding to the wrapped backend when it supports
+// pinging so /readyz still sees the circuit breaker's open state. When the wrapped backend
+// doesn't implement Pinger, Ping returns nil without consulting the breaker, so the backend
+// reads as always ready.
+func (b *CircuitBreakerBackend) Ping(ctx context.Context) error {
+	pinger, ok := b.wrapped.(Pinger)
+	if !ok {
+		return nil
+	}
+	if !b.allow() {
+		circuitBreakerShortCircuits.WithLabelValues(b.name, "ping").Inc()
+		return utils.NewPBCError(utils.BACKEND_UNAVAILABLE)
+	}
+
+	err := pinger.Ping(ctx)
+	b.recordResult(err)
+	return err
+}
+
+// GetMulti implements BatchBackend, forwarding to the wrapped backend when it supports
+// batching.
+func (b *CircuitBreakerBackend) GetMulti(ctx context.Context, keys []string) (map[string]string, error) {
+	batch, ok := b.wrapped.(BatchBackend)
+	if !ok {
+		return nil, utils.NewPBCError(utils.GET_INTERNAL_SERVER, "backend does not support GetMulti")
+	}
+	if !b.allow() {
+		circuitBreakerShortCircuits.WithLabelValues(b.name, "getmulti").Inc()
+		return nil, utils.NewPBCError(utils.BACKEND_UNAVAILABLE)
+	}
+
+	vals, err := batch.GetMulti(ctx, keys)
+	b.recordResult(err)
+	return vals, err
+}
+
+// PutMulti implements BatchBackend, forwarding to the wrapped backend when it supports
+// batching.
+func (b *CircuitBreakerBackend) PutMulti(ctx context.Context, entries []PutRequest) ([]error, error) {
+	batch, ok := b.wrapped.(BatchBackend)
+	if !ok {
+		return nil, utils.NewPBCError(utils.PUT_INTERNAL_SERVER, "backend does not support PutMulti")
+	}
+	if !b.allow() {
+		circuitBreakerShortCircuits.WithLabelValues(b.name, "putmulti").Inc()
+		return nil, utils.NewPBCError(utils.BACKEND_UNAVAILABLE)
+	}
+
+	errs, err := batch.PutMulti(ctx, entries)
+	b.recordResult(err)
+	return errs, err
+}
+
+// allow reports whether a call should be let through, advancing the state machine from
+// open to half-open once OpenStateDuration has elapsed.
+func (b *CircuitBreakerBackend) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitClosed:
+		return true
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenStateDuration {
+			return false
+		}
+		b.setState(circuitHalfOpen)
+		fallthrough
+	case circuitHalfOpen:
+		if b.halfOpenInFlight >= b.cfg.HalfOpenConcurrency {
+			return false
+		}
+		b.halfOpenInFlight++
+		return true
+	}
+	return true
+}
+
+// recordResult feeds a call's outcome back into the state machine. Expected, non-backend
+// errors (cache misses, "already exists") never count as failures.
+func (b *CircuitBreakerBackend) recordResult(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen && b.halfOpenInFlight > 0 {
+		b.halfOpenInFlight--
+	}
+
+	if err == nil || !isBackendFailure(err) {
+		if b.state == circuitHalfOpen {
+			b.setState(circuitClosed)
+			b.failureTimes = nil
+		}
+		return
+	}
+
+	now := time.Now()
+	b.failureTimes = append(b.failureTimes, now)
+	b.failureTimes = dropOlderThan(b.failureTimes, now.Add(-b.cfg.Window))
+
+	if b.state == circuitHalfOpen || len(b.failureTimes) >= b.cfg.FailureThreshold {
+		b.setState(circuitOpen)
+		b.openedAt = now
+	}
+}
+
+// setState must be called with b.mu held.
+func (b *CircuitBreakerBackend) setState(s circuitState) {
+	if b.state == s {
+		return
+	}
+	log.Infof("Circuit breaker for backend %q transitioning %s -> %s", b.name, b.state, s)
+	b.state = s
+	b.halfOpenInFlight = 0
+	circuitBreakerTransitions.WithLabelValues(b.name, s.String()).Inc()
+}
+
+// dropOlderThan returns the suffix of times that are not before cutoff. times is assumed
+// to be in non-decreasing order, as failureTimes always is.
+func dropOlderThan(times []time.Time, cutoff time.Time) []time.Time {
+	for i, t := range times {
+		if !t.Before(cutoff) {
+			return times[i:]
+		}
+	}
+	return nil
+}
+
+// isBackendFailure reports whether err represents an actual backend/network problem, as
+// opposed to an expected outcome like a cache miss or a duplicate key that shouldn't count
+// against the circuit breaker.
+func isBackendFailure(err error) bool {
+	var pbcErr *utils.PBCError
+	if errors.As(err, &pbcErr) {
+		return pbcErr.StatusCode != utils.KEY_NOT_FOUND && pbcErr.StatusCode != utils.RECORD_EXISTS
+	}
+	return true
+}
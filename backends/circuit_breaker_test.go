@@ -0,0 +1,162 @@
+package backends
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-cache/config"
+	"github.com/prebid/prebid-cache/utils"
+)
+
+// countingBackend is a Backend whose Get always returns the next error off errs (or nil once
+// errs is exhausted), so tests can script a sequence of failures/successes.
+type countingBackend struct {
+	errs  []error
+	calls int
+}
+
+func (b *countingBackend) Get(ctx context.Context, key string) (string, error) {
+	b.calls++
+	if b.calls <= len(b.errs) {
+		return "", b.errs[b.calls-1]
+	}
+	return "ok", nil
+}
+
+func (b *countingBackend) Put(ctx context.Context, key string, value string, ttlSeconds int) error {
+	return nil
+}
+
+func TestCircuitBreakerOpensAfterFailureThresholdWithinWindow(t *testing.T) {
+	backend := &countingBackend{errs: []error{errors.New("boom"), errors.New("boom"), errors.New("boom")}}
+	cb := NewCircuitBreakerBackend("test", backend, config.CircuitBreaker{
+		FailureThreshold: 3,
+		Window:           time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := cb.Get(context.Background(), "k"); err == nil {
+			t.Fatalf("call %d: expected the backend's own error, got nil", i)
+		}
+	}
+
+	if _, err := cb.Get(context.Background(), "k"); err == nil {
+		t.Fatal("expected BACKEND_UNAVAILABLE once FailureThreshold is reached, got nil")
+	}
+	if backend.calls != 3 {
+		t.Fatalf("expected the breaker to short-circuit the 4th call instead of reaching the backend, got %d backend calls", backend.calls)
+	}
+}
+
+func TestCircuitBreakerDropsFailuresOlderThanWindow(t *testing.T) {
+	backend := &countingBackend{errs: []error{errors.New("boom"), errors.New("boom")}}
+	cb := NewCircuitBreakerBackend("test", backend, config.CircuitBreaker{
+		FailureThreshold: 2,
+		Window:           10 * time.Millisecond,
+	})
+
+	if _, err := cb.Get(context.Background(), "k"); err == nil {
+		t.Fatal("expected the first failure to surface")
+	}
+
+	time.Sleep(20 * time.Millisecond) // first failure ages out of the window
+
+	if _, err := cb.Get(context.Background(), "k"); err == nil {
+		t.Fatal("expected the second failure to surface")
+	}
+	if backend.calls != 2 {
+		t.Fatalf("expected both calls to reach the backend since the first failure aged out, got %d backend calls", backend.calls)
+	}
+	if cb.state != circuitClosed {
+		t.Fatalf("expected the breaker to stay closed since only one failure is within the window, got state %s", cb.state)
+	}
+}
+
+func TestCircuitBreakerHalfOpenClosesOnSuccess(t *testing.T) {
+	backend := &countingBackend{errs: []error{errors.New("boom"), errors.New("boom")}}
+	cb := NewCircuitBreakerBackend("test", backend, config.CircuitBreaker{
+		FailureThreshold:  2,
+		Window:            time.Minute,
+		OpenStateDuration: 10 * time.Millisecond,
+	})
+
+	for i := 0; i < 2; i++ {
+		cb.Get(context.Background(), "k")
+	}
+	if _, err := cb.Get(context.Background(), "k"); err == nil {
+		t.Fatal("expected the breaker to be open and short-circuit")
+	}
+	if backend.calls != 2 {
+		t.Fatalf("expected the breaker to stay open and not reach the backend, got %d calls", backend.calls)
+	}
+
+	time.Sleep(20 * time.Millisecond) // let OpenStateDuration elapse
+
+	if _, err := cb.Get(context.Background(), "k"); err != nil {
+		t.Fatalf("expected the half-open probe to reach the backend and succeed, got error: %s", err.Error())
+	}
+	if backend.calls != 3 {
+		t.Fatalf("expected the half-open probe to reach the backend, got %d calls", backend.calls)
+	}
+
+	// A successful half-open probe should close the breaker, so the next call reaches the
+	// backend normally instead of short-circuiting.
+	if _, err := cb.Get(context.Background(), "k"); err != nil {
+		t.Fatalf("expected the breaker to be closed after a successful probe, got error: %s", err.Error())
+	}
+	if backend.calls != 4 {
+		t.Fatalf("expected the breaker to stay closed and keep reaching the backend, got %d calls", backend.calls)
+	}
+}
+
+func TestCircuitBreakerHalfOpenLimitsConcurrentProbes(t *testing.T) {
+	backend := &countingBackend{errs: []error{errors.New("boom"), errors.New("boom")}}
+	cb := NewCircuitBreakerBackend("test", backend, config.CircuitBreaker{
+		FailureThreshold:    2,
+		Window:              time.Minute,
+		OpenStateDuration:   10 * time.Millisecond,
+		HalfOpenConcurrency: 1,
+	})
+
+	for i := 0; i < 2; i++ {
+		cb.Get(context.Background(), "k")
+	}
+	time.Sleep(20 * time.Millisecond) // let OpenStateDuration elapse
+
+	cb.mu.Lock()
+	cb.state = circuitHalfOpen
+	cb.halfOpenInFlight = 1 // simulate one probe already in flight
+	cb.mu.Unlock()
+
+	if _, err := cb.Get(context.Background(), "k"); err == nil {
+		t.Fatal("expected a second concurrent half-open probe to be rejected")
+	}
+	if backend.calls != 2 {
+		t.Fatalf("expected the rejected probe to never reach the backend, got %d calls", backend.calls)
+	}
+}
+
+func TestCircuitBreakerIgnoresExpectedErrors(t *testing.T) {
+	backend := &countingBackend{}
+	cb := NewCircuitBreakerBackend("test", backend, config.CircuitBreaker{
+		FailureThreshold: 1,
+		Window:           time.Minute,
+	})
+
+	if !isBackendFailure(errors.New("boom")) {
+		t.Fatal("a plain error should count as a backend failure")
+	}
+
+	cb.recordResult(nil)
+	if cb.state != circuitClosed {
+		t.Fatalf("expected the breaker to stay closed after a nil error, got state %s", cb.state)
+	}
+
+	cb.recordResult(utils.NewPBCError(utils.KEY_NOT_FOUND))
+	cb.recordResult(utils.NewPBCError(utils.RECORD_EXISTS))
+	if cb.state != circuitClosed {
+		t.Fatalf("expected KEY_NOT_FOUND and RECORD_EXISTS not to count against a FailureThreshold of 1, got state %s", cb.state)
+	}
+}
@@ -2,7 +2,6 @@ package backends
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -53,7 +52,7 @@ func (c *igClient) DoRequest(ctx context.Context, url *url.URL, headers http.Hea
 
 	httpResp, httpErr := c.client.Do(httpReq)
 	if httpErr != nil {
-		return nil, httpErr
+		return nil, &igniteRequestError{err: httpErr}
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
@@ -63,9 +62,9 @@ func (c *igClient) DoRequest(ctx context.Context, url *url.URL, headers http.Hea
 	if httpResp.Body == nil {
 		errMsg := "Received empty httpResp.Body"
 		if httpErr == nil {
-			return nil, fmt.Errorf("Ignite error. %s", errMsg)
+			return nil, &igniteRequestError{err: fmt.Errorf("Ignite error. %s", errMsg), statusCode: httpResp.StatusCode, reachedServer: true}
 		}
-		return nil, fmt.Errorf("%s; %s", httpErr.Error(), errMsg)
+		return nil, &igniteRequestError{err: fmt.Errorf("%s; %s", httpErr.Error(), errMsg), statusCode: httpResp.StatusCode, reachedServer: true}
 	}
 	defer httpResp.Body.Close()
 
@@ -73,12 +72,16 @@ func (c *igClient) DoRequest(ctx context.Context, url *url.URL, headers http.Hea
 	if ioErr != nil {
 		errMsg := fmt.Sprintf("IO reader error: %s", ioErr)
 		if httpErr == nil {
-			return nil, fmt.Errorf("Ignite error. %s", errMsg)
+			return nil, &igniteRequestError{err: fmt.Errorf("Ignite error. %s", errMsg), statusCode: httpResp.StatusCode, reachedServer: true}
 		}
-		return nil, fmt.Errorf("%s; %s", httpErr.Error(), errMsg)
+		return nil, &igniteRequestError{err: fmt.Errorf("%s; %s", httpErr.Error(), errMsg), statusCode: httpResp.StatusCode, reachedServer: true}
 	}
 
-	return responseBody, httpErr
+	if httpErr != nil {
+		return nil, &igniteRequestError{err: httpErr, statusCode: httpResp.StatusCode, reachedServer: true}
+	}
+
+	return responseBody, nil
 }
 
 // IgniteBackend implements Backend interface and communicates with the Apache Ignite storage
@@ -91,37 +94,38 @@ type IgniteBackend struct {
 }
 
 // NewIgniteBackend expects a valid config.IgniteBackend object and will create an Apache Ignite cache in the
-// Ignite server if the config.Ignite.Cache.CreateOnStart flag is set to true
-func NewIgniteBackend(cfg config.Ignite) *IgniteBackend {
+// Ignite server if the config.Ignite.Cache.CreateOnStart flag is set to true. The returned
+// Backend is wrapped in a circuit breaker so a downed Ignite cluster fails fast instead of
+// consuming every caller's timeout.
+func NewIgniteBackend(cfg config.Ignite) Backend {
 
 	if len(cfg.Scheme) == 0 || len(cfg.Host) == 0 || cfg.Port == 0 || len(cfg.Cache.Name) == 0 {
-		errMsg := "Error creating Ignite backend: configuration is missing ignite.schema, ignite.host, ignite.port or ignite.cache.name"
-		log.Fatalf(errMsg)
-		panic(errMsg)
+		log.Fatalf("Error creating Ignite backend: configuration is missing ignite.schema, ignite.host, ignite.port or ignite.cache.name")
 	}
 	completeHost := fmt.Sprintf("%s://%s:%d/ignite", cfg.Scheme, cfg.Host, cfg.Port)
 
 	url, err := url.Parse(fmt.Sprintf("%s?cacheName=%s", completeHost, cfg.Cache.Name))
 	if err != nil {
-		errMsg := fmt.Sprintf("Error creating Ignite backend: error parsing Ignite host URL %s", err.Error())
-		log.Fatalf(errMsg)
-		panic(errMsg)
+		log.Fatalf("Error creating Ignite backend: error parsing Ignite host URL %s", err.Error())
 	}
 
 	igb := &IgniteBackend{serverURL: url}
 	if cfg.Secure {
+		tlsConfig, tlsErr := buildIgniteTLSConfig(cfg.TLS)
+		if tlsErr != nil {
+			log.Fatalf("Error creating Ignite backend: invalid TLS configuration: %s", tlsErr.Error())
+		}
 		igb.client = &igClient{
-			client: http.DefaultClient,
+			client: &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
 		}
 	} else {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
 		igb.client = &igClient{
-			client: &http.Client{Transport: tr},
+			client: http.DefaultClient,
 		}
 	}
 
+	igb.client = newRetryableIgniteClient(igb.client, cfg.Retry)
+
 	if len(cfg.Headers) > 0 {
 		for k, v := range cfg.Headers {
 			igb.headers.Add(k, v)
@@ -131,14 +135,12 @@ func NewIgniteBackend(cfg config.Ignite) *IgniteBackend {
 	if cfg.Cache.CreateOnStart {
 		igb.cacheName = cfg.Cache.Name
 		if err := createCache(igb); err != nil {
-			errMsg := fmt.Sprintf("Error creating Ignite backend: %s", err.Error())
-			log.Fatalf(errMsg)
-			panic(errMsg)
+			log.Fatalf("Error creating Ignite backend: %s", err.Error())
 		}
 	}
 	log.Infof("Prebid Cache will write to Ignite cache name: %s", cfg.Cache.Name)
 
-	return igb
+	return NewCircuitBreakerBackend("ignite", igb, cfg.CircuitBreaker)
 }
 
 // createCache uses the Apache Ignite REST API "getorcreate" command to create a cache
@@ -183,6 +185,17 @@ func createCache(igb *IgniteBackend) error {
 //	Status   int    `json:"successStatus"`
 //}
 
+// Ping implements the Pinger interface by issuing a lightweight Get against a sentinel key
+// that isn't expected to exist, so /readyz can confirm Ignite is reachable without needing a
+// real cache entry around. A KEY_NOT_FOUND response still counts as reachable.
+func (back *IgniteBackend) Ping(ctx context.Context) error {
+	_, err := back.Get(ctx, readinessSentinelKey)
+	if pbcErr, ok := err.(*utils.PBCError); ok && pbcErr.StatusCode == utils.KEY_NOT_FOUND {
+		return nil
+	}
+	return err
+}
+
 // Get implements the Backend interface. Makes the Ignite storage client retrieve the value that has
 // been previously stored under 'key' if its TTL is still current. We can tell when a key is not found
 // when Ignite doesn't return an error, nor a 'Status' different than zero, but the 'Response' field is
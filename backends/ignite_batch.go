@@ -0,0 +1,115 @@
+package backends
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/prebid/prebid-cache/utils"
+)
+
+// PutRequest is a single key/value/TTL tuple passed to BatchBackend.PutMulti.
+type PutRequest struct {
+	Key        string
+	Value      string
+	TTLSeconds int
+}
+
+// BatchBackend is implemented by backends that can service several keys in one round-trip.
+// Backends that can't batch simply don't implement it; callers type-assert for it and fall
+// back to looping over Get/Put.
+//
+// Only GetMulti is actually batched. Ignite's REST API has no command that reports per-key
+// existence in a single round-trip ("containskeys" only answers "are ALL of these present"),
+// so there's no way to honor Put's putifabs semantics for a batch of new and pre-existing
+// keys without either a per-key existence check or a per-key write - which costs exactly as
+// many round-trips as never batching at all. PutMulti exists so callers can use one interface
+// for both directions, but it's a per-key putifabs loop under the hood; see its doc comment.
+type BatchBackend interface {
+	GetMulti(ctx context.Context, keys []string) (map[string]string, error)
+	PutMulti(ctx context.Context, entries []PutRequest) ([]error, error)
+}
+
+// GetMulti implements BatchBackend using the Ignite REST API's "getall" command, trading
+// the per-key round-trip of Get for a single call that returns every value at once.
+func (back *IgniteBackend) GetMulti(ctx context.Context, keys []string) (map[string]string, error) {
+	if len(keys) == 0 {
+		return map[string]string{}, nil
+	}
+
+	urlCopy := *back.serverURL
+	q := urlCopy.Query()
+	q.Set("cmd", "getall")
+	for i, key := range keys {
+		q.Set(fmt.Sprintf("k%d", i+1), key)
+	}
+	urlCopy.RawQuery = q.Encode()
+
+	responseBytes, err := back.client.DoRequest(ctx, &urlCopy, back.headers)
+	if err != nil {
+		return nil, err
+	}
+
+	igniteResponse := struct {
+		Error    string            `json:"error"`
+		Response map[string]string `json:"response"`
+		Status   int               `json:"successStatus"`
+	}{}
+	if unmarshalErr := json.Unmarshal(responseBytes, &igniteResponse); unmarshalErr != nil {
+		return nil, fmt.Errorf("Unmarshal response error: %s; Response body: %s", unmarshalErr.Error(), string(responseBytes))
+	}
+
+	if len(igniteResponse.Error) > 0 {
+		return nil, utils.NewPBCError(utils.GET_INTERNAL_SERVER, igniteResponse.Error)
+	}
+	if igniteResponse.Status > 0 {
+		return nil, utils.NewPBCError(utils.GET_INTERNAL_SERVER, "Ignite response.Status not zero")
+	}
+
+	return igniteResponse.Response, nil
+}
+
+// PutMulti implements BatchBackend by looping the regular putifabs-backed Put over each
+// entry. This is deliberately not batched: Ignite's REST API has no "putall if absent"
+// command, and the only way to learn which keys already exist before writing is
+// "containskeys", which answers "are ALL of these present" rather than per-key. For the
+// common case of a batch of brand-new, never-before-seen keys that answer is false, which
+// would force a per-key "containskey" fallback before the write even starts - trading the N
+// round-trips a per-key Put already costs for 1+N+1. So PutMulti buys callers a single
+// interface for both directions, not fewer round-trips; see BatchBackend's doc comment. The
+// per-key Put calls are still independent writes to distinct keys, so they're issued
+// concurrently to at least collapse the batch's wall-clock latency to one round-trip.
+func (back *IgniteBackend) PutMulti(ctx context.Context, entries []PutRequest) ([]error, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	errs := make([]error, len(entries))
+	var wg sync.WaitGroup
+	wg.Add(len(entries))
+	for i, entry := range entries {
+		go func(i int, entry PutRequest) {
+			defer wg.Done()
+			errs[i] = back.Put(ctx, entry.Key, entry.Value, entry.TTLSeconds)
+		}(i, entry)
+	}
+	wg.Wait()
+
+	return errs, firstError(errs)
+}
+
+// firstError returns the first genuine backend failure in errs, so a failure buried in a
+// per-key result slice still surfaces as a call-level error for callers like
+// CircuitBreakerBackend that only inspect PutMulti's second return value. Expected per-key
+// outcomes (RECORD_EXISTS, KEY_NOT_FOUND) are deliberately not promoted to a call-level
+// error: a batch of otherwise-successful writes with one duplicate key is not a failed call,
+// and a caller that only checks PutMulti's second return value shouldn't be told it is.
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil && isBackendFailure(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package backends
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// simulatedIgniteRTT stands in for the network round-trip a real Ignite REST call pays on
+// every request, so these benchmarks show what batching actually buys: one round-trip for
+// a whole key set instead of one per key.
+const simulatedIgniteRTT = 200 * time.Microsecond
+
+// latencyIgniteClient is an igniteClient that sleeps simulatedIgniteRTT per call and answers
+// "get"/"getall" with just enough JSON to keep IgniteBackend.Get/GetMulti happy.
+type latencyIgniteClient struct{}
+
+func (latencyIgniteClient) DoRequest(ctx context.Context, u *url.URL, headers http.Header) ([]byte, error) {
+	time.Sleep(simulatedIgniteRTT)
+
+	switch u.Query().Get("cmd") {
+	case "getall":
+		keys := []string{}
+		for i := 1; ; i++ {
+			key := u.Query().Get(fmt.Sprintf("k%d", i))
+			if key == "" {
+				break
+			}
+			keys = append(keys, key)
+		}
+		body := `{"successStatus":0,"error":"","response":{`
+		for i, key := range keys {
+			if i > 0 {
+				body += ","
+			}
+			body += fmt.Sprintf(`"%s":"value-%s"`, key, key)
+		}
+		body += "}}"
+		return []byte(body), nil
+	case "putifabs":
+		return []byte(`{"successStatus":0,"error":"","response":true}`), nil
+	}
+
+	key := u.Query().Get("key")
+	return []byte(fmt.Sprintf(`{"successStatus":0,"error":"","response":"value-%s"}`, key)), nil
+}
+
+func benchmarkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+	return keys
+}
+
+// BenchmarkIgniteGetPerKey issues one Get per key, the pre-batching tail latency path.
+func BenchmarkIgniteGetPerKey(b *testing.B) {
+	back := &IgniteBackend{
+		serverURL: mustParseBenchURL(b, "http://ignite:8080/ignite?cacheName=bench"),
+		client:    latencyIgniteClient{},
+	}
+	keys := benchmarkKeys(20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			if _, err := back.Get(context.Background(), key); err != nil {
+				b.Fatalf("unexpected error: %s", err.Error())
+			}
+		}
+	}
+}
+
+// BenchmarkIgniteGetMulti issues the same key set as one batched GetMulti call.
+func BenchmarkIgniteGetMulti(b *testing.B) {
+	back := &IgniteBackend{
+		serverURL: mustParseBenchURL(b, "http://ignite:8080/ignite?cacheName=bench"),
+		client:    latencyIgniteClient{},
+	}
+	keys := benchmarkKeys(20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := back.GetMulti(context.Background(), keys); err != nil {
+			b.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+}
+
+func benchmarkPutRequests(n int) []PutRequest {
+	entries := make([]PutRequest, n)
+	for i := range entries {
+		entries[i] = PutRequest{Key: fmt.Sprintf("key-%d", i), Value: fmt.Sprintf("value-%d", i), TTLSeconds: 60}
+	}
+	return entries
+}
+
+// BenchmarkIgnitePutPerKey issues one putifabs-backed Put per key.
+func BenchmarkIgnitePutPerKey(b *testing.B) {
+	back := &IgniteBackend{
+		serverURL: mustParseBenchURL(b, "http://ignite:8080/ignite?cacheName=bench"),
+		client:    latencyIgniteClient{},
+	}
+	entries := benchmarkPutRequests(20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, entry := range entries {
+			if err := back.Put(context.Background(), entry.Key, entry.Value, entry.TTLSeconds); err != nil {
+				b.Fatalf("unexpected error: %s", err.Error())
+			}
+		}
+	}
+}
+
+// BenchmarkIgnitePutMulti issues the same entries through PutMulti. PutMulti is still a
+// putifabs loop under the hood (see its doc comment) rather than a single Ignite command,
+// since Ignite has no batched command that preserves put-if-absent semantics - but the
+// per-key calls are independent writes, so PutMulti fires them concurrently and should still
+// collapse the batch's wall-clock latency to roughly one round-trip, same as GetMulti.
+func BenchmarkIgnitePutMulti(b *testing.B) {
+	back := &IgniteBackend{
+		serverURL: mustParseBenchURL(b, "http://ignite:8080/ignite?cacheName=bench"),
+		client:    latencyIgniteClient{},
+	}
+	entries := benchmarkPutRequests(20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := back.PutMulti(context.Background(), entries); err != nil {
+			b.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+}
+
+func mustParseBenchURL(b *testing.B, raw string) *url.URL {
+	b.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		b.Fatalf("failed to parse benchmark URL: %s", err.Error())
+	}
+	return u
+}
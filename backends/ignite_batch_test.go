@@ -0,0 +1,175 @@
+package backends
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/prebid/prebid-cache/utils"
+)
+
+// fakeBatchIgniteClient is a scriptable igniteClient covering the "getall" and "putifabs"
+// commands GetMulti/PutMulti rely on. PutMulti issues its putifabs calls concurrently, so
+// calls is guarded by callsMu.
+type fakeBatchIgniteClient struct {
+	getAllResponse map[string]string
+	getAllErr      string
+
+	present map[string]bool // keys that already exist, so putifabs reports RECORD_EXISTS
+	putErr  map[string]string
+
+	callsMu sync.Mutex
+	calls   []string
+}
+
+func (c *fakeBatchIgniteClient) DoRequest(ctx context.Context, u *url.URL, headers http.Header) ([]byte, error) {
+	cmd := u.Query().Get("cmd")
+	c.callsMu.Lock()
+	c.calls = append(c.calls, cmd)
+	c.callsMu.Unlock()
+
+	switch cmd {
+	case "getall":
+		return json.Marshal(struct {
+			Error    string            `json:"error"`
+			Response map[string]string `json:"response"`
+			Status   int               `json:"successStatus"`
+		}{Error: c.getAllErr, Response: c.getAllResponse})
+	case "putifabs":
+		key := u.Query().Get("key")
+		if errMsg, ok := c.putErr[key]; ok {
+			return json.Marshal(struct {
+				Error  string `json:"error"`
+				Status int    `json:"successStatus"`
+			}{Error: errMsg})
+		}
+		return json.Marshal(struct {
+			Error    string `json:"error"`
+			Response bool   `json:"response"`
+			Status   int    `json:"successStatus"`
+		}{Response: !c.present[key]})
+	default:
+		return nil, fmt.Errorf("fakeBatchIgniteClient: unexpected cmd %q", cmd)
+	}
+}
+
+func newBatchTestBackend(client igniteClient) *IgniteBackend {
+	return &IgniteBackend{
+		serverURL: mustParseURLForBatchTest("http://ignite:8080/ignite?cacheName=test"),
+		client:    client,
+	}
+}
+
+func mustParseURLForBatchTest(raw string) *url.URL {
+	u, err := url.Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func TestIgniteGetMultiOmitsMissingKeys(t *testing.T) {
+	client := &fakeBatchIgniteClient{getAllResponse: map[string]string{"a": "1"}}
+	back := newBatchTestBackend(client)
+
+	values, err := back.GetMulti(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if _, ok := values["b"]; ok {
+		t.Fatal("expected GetMulti to omit a key Ignite doesn't have, not zero-value it")
+	}
+	if values["a"] != "1" {
+		t.Fatalf("expected a=1, got %q", values["a"])
+	}
+}
+
+func TestIgniteGetMultiReturnsIgniteError(t *testing.T) {
+	client := &fakeBatchIgniteClient{getAllErr: "boom"}
+	back := newBatchTestBackend(client)
+
+	if _, err := back.GetMulti(context.Background(), []string{"a"}); err == nil {
+		t.Fatal("expected an error from a failing getall call")
+	}
+}
+
+func TestIgnitePutMultiAllNewEntriesSucceed(t *testing.T) {
+	client := &fakeBatchIgniteClient{present: map[string]bool{}}
+	back := newBatchTestBackend(client)
+
+	errs, err := back.PutMulti(context.Background(), []PutRequest{
+		{Key: "a", Value: "1", TTLSeconds: 60},
+		{Key: "b", Value: "2", TTLSeconds: 60},
+	})
+	if err != nil {
+		t.Fatalf("unexpected call-level error: %s", err.Error())
+	}
+	for i, e := range errs {
+		if e != nil {
+			t.Fatalf("entry %d: unexpected error: %s", i, e.Error())
+		}
+	}
+	for _, cmd := range client.calls {
+		if cmd != "putifabs" {
+			t.Fatalf("expected every entry to go through putifabs, got %q", cmd)
+		}
+	}
+}
+
+func TestIgnitePutMultiExistingKeyGetsRecordExistsButNoCallLevelError(t *testing.T) {
+	client := &fakeBatchIgniteClient{present: map[string]bool{"a": true}}
+	back := newBatchTestBackend(client)
+
+	errs, err := back.PutMulti(context.Background(), []PutRequest{{Key: "a", Value: "1", TTLSeconds: 60}})
+	if err != nil {
+		t.Fatalf("expected RECORD_EXISTS to not surface as a call-level error, got %v", err)
+	}
+	if len(errs) != 1 || errs[0] == nil {
+		t.Fatal("expected the per-key error slice to carry RECORD_EXISTS")
+	}
+	if pbcErr, ok := errs[0].(*utils.PBCError); !ok || pbcErr.StatusCode != utils.RECORD_EXISTS {
+		t.Fatalf("expected RECORD_EXISTS, got %v", errs[0])
+	}
+}
+
+func TestIgnitePutMultiMixedNewAndExistingKeepsIndependentResults(t *testing.T) {
+	client := &fakeBatchIgniteClient{present: map[string]bool{"a": true}}
+	back := newBatchTestBackend(client)
+
+	errs, err := back.PutMulti(context.Background(), []PutRequest{
+		{Key: "a", Value: "1", TTLSeconds: 30},
+		{Key: "b", Value: "2", TTLSeconds: 60},
+	})
+	if err != nil {
+		t.Fatalf("a single RECORD_EXISTS among otherwise-successful writes must not be a call-level error, got %v", err)
+	}
+	if errs[0] == nil {
+		t.Fatal("expected entry 0 (pre-existing key) to carry RECORD_EXISTS")
+	}
+	if errs[1] != nil {
+		t.Fatalf("expected entry 1 (new key) to succeed, got %s", errs[1].Error())
+	}
+}
+
+func TestIgnitePutMultiPropagatesBackendFailureAsCallLevelError(t *testing.T) {
+	client := &fakeBatchIgniteClient{present: map[string]bool{}, putErr: map[string]string{"b": "ignite is down"}}
+	back := newBatchTestBackend(client)
+
+	errs, err := back.PutMulti(context.Background(), []PutRequest{
+		{Key: "a", Value: "1", TTLSeconds: 60},
+		{Key: "b", Value: "2", TTLSeconds: 60},
+	})
+	if err == nil {
+		t.Fatal("expected a failed putifabs to surface as a call-level error")
+	}
+	if errs[0] != nil {
+		t.Fatalf("entry 0: expected success, got %s", errs[0].Error())
+	}
+	if errs[1] == nil {
+		t.Fatal("entry 1: expected the backend failure to land in the per-key errs slice too")
+	}
+}
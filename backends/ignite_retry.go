@@ -0,0 +1,152 @@
+package backends
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prebid/prebid-cache/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultIgniteRetryMax, defaultIgniteRetryWaitMin and defaultIgniteRetryWaitMax are used
+// whenever the operator leaves config.Ignite.Retry unset, so a bare-bones config still
+// survives a brief Ignite reboot. A call that fails every attempt can take tens of seconds
+// to give up (see defaultCircuitBreakerWindow in circuit_breaker.go, which is sized against
+// this worst case), so NewIgniteBackend wraps the result in a circuit breaker rather than
+// letting every caller pay that latency during an outage.
+const (
+	defaultIgniteRetryMax     = 4
+	defaultIgniteRetryWaitMin = 500 * time.Millisecond
+	defaultIgniteRetryWaitMax = 30 * time.Second
+)
+
+// igniteRequestError wraps a failed DoRequest call with enough information for the retry
+// policy to tell a failure that never reached the Ignite server (safe to retry even for
+// putifabs) apart from one where the server may already have applied the command.
+type igniteRequestError struct {
+	err           error
+	statusCode    int  // zero when no HTTP response was ever received
+	reachedServer bool // true once an HTTP response, even a bad one, came back
+}
+
+func (e *igniteRequestError) Error() string { return e.err.Error() }
+func (e *igniteRequestError) Unwrap() error { return e.err }
+
+// retryableIgniteClient decorates an igniteClient with retries over transient failures,
+// using a decorrelated-jitter backoff so a fleet of Prebid Cache instances recovering from
+// an Ignite blip doesn't hammer it back down in lockstep. See
+// https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+type retryableIgniteClient struct {
+	wrapped  igniteClient
+	retryMax int
+	waitMin  time.Duration
+	waitMax  time.Duration
+}
+
+// newRetryableIgniteClient builds a retryableIgniteClient out of cfg, falling back to the
+// package defaults for any knob the operator left unset.
+func newRetryableIgniteClient(wrapped igniteClient, cfg config.IgniteRetry) igniteClient {
+	retryMax := cfg.RetryMax
+	if retryMax <= 0 {
+		retryMax = defaultIgniteRetryMax
+	}
+	waitMin := cfg.RetryWaitMin
+	if waitMin <= 0 {
+		waitMin = defaultIgniteRetryWaitMin
+	}
+	waitMax := cfg.RetryWaitMax
+	if waitMax <= 0 {
+		waitMax = defaultIgniteRetryWaitMax
+	}
+
+	return &retryableIgniteClient{
+		wrapped:  wrapped,
+		retryMax: retryMax,
+		waitMin:  waitMin,
+		waitMax:  waitMax,
+	}
+}
+
+// DoRequest implements the igniteClient interface, retrying the wrapped call while
+// shouldRetryIgniteRequest says the failure is transient.
+func (c *retryableIgniteClient) DoRequest(ctx context.Context, u *url.URL, headers http.Header) ([]byte, error) {
+	wait := c.waitMin
+
+	for attempt := 0; ; attempt++ {
+		body, err := c.wrapped.DoRequest(ctx, u, headers)
+		if err == nil || !shouldRetryIgniteRequest(u, err, attempt, c.retryMax) {
+			return body, err
+		}
+
+		log.Warnf("Ignite request to %s failed, retrying (attempt %d/%d): %s", u.Path, attempt+1, c.retryMax, err.Error())
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return body, err
+		}
+		wait = nextIgniteBackoff(wait, c.waitMin, c.waitMax)
+	}
+}
+
+// nextIgniteBackoff picks the next decorrelated-jitter wait: a random duration between
+// waitMin and three times the previous wait, capped at waitMax.
+func nextIgniteBackoff(prev, waitMin, waitMax time.Duration) time.Duration {
+	spread := int64(prev)*3 - int64(waitMin)
+	if spread <= 0 {
+		return waitMin
+	}
+	next := waitMin + time.Duration(rand.Int63n(spread+1))
+	if next > waitMax {
+		return waitMax
+	}
+	return next
+}
+
+// shouldRetryIgniteRequest retries net.Error timeouts, dial failures like connection
+// refused (a rebooting Ignite node refuses far more often than it times out), io.EOF and
+// 429/500/502/503/504 responses, but never any other 4xx. A "putifabs" request is retried
+// only after a dial failure, since any other error may come after the Ignite server already
+// applied it, and replaying it could mask a legitimate RECORD_EXISTS.
+func shouldRetryIgniteRequest(u *url.URL, err error, attempt, retryMax int) bool {
+	if attempt >= retryMax {
+		return false
+	}
+
+	var reqErr *igniteRequestError
+	reachedServer := errors.As(err, &reqErr) && reqErr.reachedServer
+	if u.Query().Get("cmd") == "putifabs" {
+		// Only a failure to connect at all proves the request never left the client; a
+		// timeout or reset while waiting on the response may follow a completed write.
+		var opErr *net.OpError
+		if reachedServer || !errors.As(err, &opErr) || opErr.Op != "dial" {
+			return false
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		// Any network-level error that never got as far as an HTTP response - timeouts,
+		// connection refused, connection reset, DNS failures - is retried; for putifabs
+		// only dial failures get this far (see above).
+		if netErr.Timeout() || !reachedServer {
+			return true
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	if reqErr != nil {
+		switch reqErr.statusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package backends
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-cache/config"
+)
+
+// fakeTimeoutError implements net.Error with Timeout() == true, the same shape a real
+// *http.Client returns for a dial/read timeout.
+type fakeTimeoutError struct{}
+
+func (fakeTimeoutError) Error() string   { return "fake timeout" }
+func (fakeTimeoutError) Timeout() bool   { return true }
+func (fakeTimeoutError) Temporary() bool { return true }
+
+// failThenSucceedClient implements httpClientWrapper. It returns a timeout error for the
+// first failCount calls, then a 200 response with body responseBody.
+type failThenSucceedClient struct {
+	failCount    int
+	responseBody string
+
+	calls     int
+	callTimes []time.Time
+}
+
+func (c *failThenSucceedClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	c.callTimes = append(c.callTimes, time.Now())
+	if c.calls <= c.failCount {
+		return nil, fakeTimeoutError{}
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(stringsReader(c.responseBody)),
+	}, nil
+}
+
+// stringsReader avoids pulling in strings.NewReader just for this test file's one use.
+type stringReader struct {
+	s string
+	i int
+}
+
+func stringsReader(s string) *stringReader { return &stringReader{s: s} }
+
+func (r *stringReader) Read(p []byte) (int, error) {
+	if r.i >= len(r.s) {
+		return 0, io.EOF
+	}
+	n := copy(p, r.s[r.i:])
+	r.i += n
+	return n, nil
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("failed to parse test URL: %s", err.Error())
+	}
+	return u
+}
+
+func TestRetryableIgniteClientRetriesTransientFailures(t *testing.T) {
+	mock := &failThenSucceedClient{failCount: 2, responseBody: `{"response":"ok","successStatus":0}`}
+	client := newRetryableIgniteClient(&igClient{client: mock}, config.IgniteRetry{
+		RetryMax:     4,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 2 * time.Millisecond,
+	})
+
+	body, err := client.DoRequest(context.Background(), mustParseURL(t, "http://ignite:8080/ignite?cmd=get"), nil)
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %s", err.Error())
+	}
+	if string(body) != `{"response":"ok","successStatus":0}` {
+		t.Fatalf("unexpected response body: %s", body)
+	}
+	if mock.calls != 3 {
+		t.Fatalf("expected 3 calls (2 failures + 1 success), got %d", mock.calls)
+	}
+}
+
+func TestRetryableIgniteClientGivesUpAfterRetryMax(t *testing.T) {
+	mock := &failThenSucceedClient{failCount: 10, responseBody: `{}`}
+	client := newRetryableIgniteClient(&igClient{client: mock}, config.IgniteRetry{
+		RetryMax:     3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 2 * time.Millisecond,
+	})
+
+	_, err := client.DoRequest(context.Background(), mustParseURL(t, "http://ignite:8080/ignite?cmd=get"), nil)
+	if err == nil {
+		t.Fatal("expected an error once RetryMax attempts are exhausted")
+	}
+	if mock.calls != 4 { // the initial attempt plus 3 retries
+		t.Fatalf("expected 4 calls (1 initial + 3 retries), got %d", mock.calls)
+	}
+}
+
+func TestRetryableIgniteClientBackoffWithinBounds(t *testing.T) {
+	waitMin := 2 * time.Millisecond
+	waitMax := 20 * time.Millisecond
+	mock := &failThenSucceedClient{failCount: 3, responseBody: `{}`}
+	client := newRetryableIgniteClient(&igClient{client: mock}, config.IgniteRetry{
+		RetryMax:     5,
+		RetryWaitMin: waitMin,
+		RetryWaitMax: waitMax,
+	})
+
+	if _, err := client.DoRequest(context.Background(), mustParseURL(t, "http://ignite:8080/ignite?cmd=get"), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	for i := 1; i < len(mock.callTimes); i++ {
+		gap := mock.callTimes[i].Sub(mock.callTimes[i-1])
+		if gap < waitMin {
+			t.Fatalf("retry %d fired after %s, before RetryWaitMin %s", i, gap, waitMin)
+		}
+		if gap > waitMax+10*time.Millisecond { // generous slack for scheduler jitter
+			t.Fatalf("retry %d fired after %s, past RetryWaitMax %s", i, gap, waitMax)
+		}
+	}
+}
+
+// readFailsAfterStatusOKClient simulates a server that accepted and applied the request
+// (status 200) but whose response body fails to read, e.g. a network blip right after the
+// server wrote its status line.
+type readFailsAfterStatusOKClient struct {
+	calls int
+}
+
+type erroringReader struct{}
+
+func (erroringReader) Read([]byte) (int, error) { return 0, io.ErrUnexpectedEOF }
+
+func (c *readFailsAfterStatusOKClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(erroringReader{}),
+	}, nil
+}
+
+func TestRetryableIgniteClientDoesNotRetryPutifabsOnceItReachedTheServer(t *testing.T) {
+	mock := &readFailsAfterStatusOKClient{}
+	client := newRetryableIgniteClient(&igClient{client: mock}, config.IgniteRetry{
+		RetryMax:     4,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 2 * time.Millisecond,
+	})
+
+	_, err := client.DoRequest(context.Background(), mustParseURL(t, "http://ignite:8080/ignite?cmd=putifabs&key=a&val=b"), nil)
+	if err == nil {
+		t.Fatal("expected the IO read failure to surface as an error")
+	}
+	if mock.calls != 1 {
+		t.Fatalf("putifabs must not be retried once the request may have reached the server; got %d calls", mock.calls)
+	}
+}
@@ -0,0 +1,66 @@
+package backends
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/prebid/prebid-cache/config"
+)
+
+// tlsVersions maps the config.Ignite.TLS.MinVersion strings an operator can set to their
+// crypto/tls constants.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// buildIgniteTLSConfig turns a config.IgniteTLS into a *tls.Config, loading the CA bundle
+// and client certificate once at startup so NewIgniteBackend can fail fast on a bad path or
+// malformed PEM instead of discovering it on the first request.
+func buildIgniteTLSConfig(cfg config.IgniteTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if len(cfg.MinVersion) > 0 {
+		version, ok := tlsVersions[cfg.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS.MinVersion %q, expected one of 1.0, 1.1, 1.2, 1.3", cfg.MinVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if len(cfg.CACertFile) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		caCert, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS.CACertFile %q: %w", cfg.CACertFile, err)
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("TLS.CACertFile %q doesn't contain a valid PEM certificate", cfg.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(cfg.ClientCertFile) > 0 || len(cfg.ClientKeyFile) > 0 {
+		if len(cfg.ClientCertFile) == 0 || len(cfg.ClientKeyFile) == 0 {
+			return nil, fmt.Errorf("TLS.ClientCertFile and TLS.ClientKeyFile must both be set to enable mTLS")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS client keypair: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
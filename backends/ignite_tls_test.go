@@ -0,0 +1,215 @@
+package backends
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-cache/config"
+)
+
+// testPKI is a minimal, from-scratch CA + server cert + client cert used to exercise
+// buildIgniteTLSConfig against a real TLS handshake, including mTLS.
+type testPKI struct {
+	caCertPEM     []byte
+	serverCertPEM []byte
+	serverKeyPEM  []byte
+	clientCertPEM []byte
+	clientKeyPEM  []byte
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating CA key: %s", err.Error())
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "prebid-cache test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	caCertDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("creating CA cert: %s", err.Error())
+	}
+	caCert, err := x509.ParseCertificate(caCertDER)
+	if err != nil {
+		t.Fatalf("parsing CA cert: %s", err.Error())
+	}
+
+	serverCertPEM, serverKeyPEM := issueLeaf(t, caCert, caKey, "127.0.0.1", x509.ExtKeyUsageServerAuth)
+	clientCertPEM, clientKeyPEM := issueLeaf(t, caCert, caKey, "ignite-client", x509.ExtKeyUsageClientAuth)
+
+	return testPKI{
+		caCertPEM:     pemEncode("CERTIFICATE", caCertDER),
+		serverCertPEM: serverCertPEM,
+		serverKeyPEM:  serverKeyPEM,
+		clientCertPEM: clientCertPEM,
+		clientKeyPEM:  clientKeyPEM,
+	}
+}
+
+func issueLeaf(t *testing.T, caCert *x509.Certificate, caKey *ecdsa.PrivateKey, commonName string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating leaf key: %s", err.Error())
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	if usage == x509.ExtKeyUsageServerAuth {
+		if ip := net.ParseIP(commonName); ip != nil {
+			template.IPAddresses = []net.IP{ip}
+		} else {
+			template.DNSNames = []string{commonName}
+		}
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("creating leaf cert: %s", err.Error())
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling leaf key: %s", err.Error())
+	}
+
+	return pemEncode("CERTIFICATE", certDER), pemEncode("EC PRIVATE KEY", keyDER)
+}
+
+func pemEncode(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func writeTemp(t *testing.T, dir, name string, contents []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, contents, 0o600); err != nil {
+		t.Fatalf("writing %s: %s", path, err.Error())
+	}
+	return path
+}
+
+// newMTLSServer starts an httptest server that requires a client certificate signed by pki's
+// CA, serving 200 OK to any request that completes the handshake.
+func newMTLSServer(t *testing.T, pki testPKI) *httptest.Server {
+	t.Helper()
+
+	serverCert, err := tls.X509KeyPair(pki.serverCertPEM, pki.serverKeyPEM)
+	if err != nil {
+		t.Fatalf("loading server keypair: %s", err.Error())
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pki.caCertPEM) {
+		t.Fatal("failed to add CA cert to client CA pool")
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	return server
+}
+
+func TestBuildIgniteTLSConfigSuccessfulMTLSHandshake(t *testing.T) {
+	pki := newTestPKI(t)
+	server := newMTLSServer(t, pki)
+	defer server.Close()
+
+	dir := t.TempDir()
+	tlsConfig, err := buildIgniteTLSConfig(config.IgniteTLS{
+		CACertFile:     writeTemp(t, dir, "ca.pem", pki.caCertPEM),
+		ClientCertFile: writeTemp(t, dir, "client.pem", pki.clientCertPEM),
+		ClientKeyFile:  writeTemp(t, dir, "client-key.pem", pki.clientKeyPEM),
+		ServerName:     "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("buildIgniteTLSConfig returned an error: %s", err.Error())
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected a successful mTLS handshake, got: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
+	}
+}
+
+func TestBuildIgniteTLSConfigHostnameMismatch(t *testing.T) {
+	pki := newTestPKI(t)
+	server := newMTLSServer(t, pki)
+	defer server.Close()
+
+	dir := t.TempDir()
+	tlsConfig, err := buildIgniteTLSConfig(config.IgniteTLS{
+		CACertFile:     writeTemp(t, dir, "ca.pem", pki.caCertPEM),
+		ClientCertFile: writeTemp(t, dir, "client.pem", pki.clientCertPEM),
+		ClientKeyFile:  writeTemp(t, dir, "client-key.pem", pki.clientKeyPEM),
+		ServerName:     "not-the-right-host",
+	})
+	if err != nil {
+		t.Fatalf("buildIgniteTLSConfig returned an error: %s", err.Error())
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("expected a hostname verification error, got none")
+	}
+}
+
+func TestBuildIgniteTLSConfigBadCA(t *testing.T) {
+	pki := newTestPKI(t)
+	server := newMTLSServer(t, pki)
+	defer server.Close()
+
+	otherPKI := newTestPKI(t)
+
+	dir := t.TempDir()
+	tlsConfig, err := buildIgniteTLSConfig(config.IgniteTLS{
+		CACertFile:     writeTemp(t, dir, "ca.pem", otherPKI.caCertPEM), // wrong CA
+		ClientCertFile: writeTemp(t, dir, "client.pem", pki.clientCertPEM),
+		ClientKeyFile:  writeTemp(t, dir, "client-key.pem", pki.clientKeyPEM),
+		ServerName:     "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("buildIgniteTLSConfig returned an error: %s", err.Error())
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("expected a certificate-signed-by-unknown-authority error, got none")
+	}
+}
@@ -0,0 +1,14 @@
+package backends
+
+import "context"
+
+// Pinger is implemented by backends that can cheaply verify connectivity to their storage,
+// for use by readiness probes. It's optional: a Backend that doesn't implement it is
+// assumed always ready.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// readinessSentinelKey is looked up by Ping. It isn't expected to exist, so a KEY_NOT_FOUND
+// response still proves the backend round-trip itself succeeded.
+const readinessSentinelKey = "__prebid_cache_readiness_probe__"
@@ -0,0 +1,56 @@
+package config
+
+import "time"
+
+// Ignite holds the configuration needed to connect Prebid Cache to an Apache Ignite
+// cluster over its REST API.
+type Ignite struct {
+	Scheme  string
+	Host    string
+	Port    int
+	Secure  bool
+	Headers map[string]string
+	Cache   IgniteCache
+
+	TLS            IgniteTLS
+	Retry          IgniteRetry
+	CircuitBreaker CircuitBreaker
+}
+
+// IgniteCache names the Ignite cache Prebid Cache reads and writes to, and whether it
+// should be created on startup if it doesn't already exist.
+type IgniteCache struct {
+	Name          string
+	CreateOnStart bool
+}
+
+// IgniteTLS configures the TLS transport used to reach a secured Ignite REST endpoint,
+// including optional mutual TLS.
+type IgniteTLS struct {
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	ServerName         string
+	MinVersion         string
+	InsecureSkipVerify bool
+}
+
+// IgniteRetry configures the retry/backoff policy wrapped around the Ignite REST client.
+// Leaving a field unset (zero value) falls back to the package default in backends.
+type IgniteRetry struct {
+	RetryMax     int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+}
+
+// CircuitBreaker configures the rolling-window circuit breaker wrapped around an Ignite
+// Backend. Leaving a field unset (zero value) falls back to the package default in
+// backends. Window should comfortably exceed the worst-case total duration of a single
+// retried call (RetryMax retries at up to RetryWaitMax apart) — otherwise consecutive
+// failures age out of the window before enough of them accumulate to trip the breaker.
+type CircuitBreaker struct {
+	FailureThreshold    int
+	Window              time.Duration
+	OpenStateDuration   time.Duration
+	HalfOpenConcurrency int
+}
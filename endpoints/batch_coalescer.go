@@ -0,0 +1,125 @@
+package endpoints
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-cache/backends"
+	"github.com/prebid/prebid-cache/utils"
+)
+
+// getCoalesceWindow is how long a GetCoalescer waits for more callers to join a batch
+// before dispatching it as a single GetMulti call. Ignite's getall round-trip dominates
+// latency far more than 5ms of added queueing ever could, so a short window here turns a
+// burst of single-key lookups into one backend call without meaningfully slowing any of
+// them down.
+const getCoalesceWindow = 5 * time.Millisecond
+
+// dispatchTimeout bounds the batched GetMulti call. dispatch can't borrow any one caller's
+// context - it serves every key that joined the window, not just whoever opened it - so it
+// needs a deadline of its own instead of inheriting one caller's cancellation.
+const dispatchTimeout = 2 * time.Second
+
+// getResult is what a coalesced lookup resolves to.
+type getResult struct {
+	value string
+	err   error
+}
+
+// GetCoalescer batches concurrent single-key Get calls that land within the same short
+// window into one backend.GetMulti call, singleflight-style. It's only useful in front of
+// a backends.BatchBackend; callers should fall back to calling Get directly otherwise.
+type GetCoalescer struct {
+	backend backends.BatchBackend
+
+	mu      sync.Mutex
+	pending map[string]*sync.WaitGroup
+	waiters map[string]int
+	results map[string]getResult
+	timer   *time.Timer
+}
+
+// NewGetCoalescer returns a GetCoalescer that dispatches batches to backend.
+func NewGetCoalescer(backend backends.BatchBackend) *GetCoalescer {
+	return &GetCoalescer{
+		backend: backend,
+		pending: make(map[string]*sync.WaitGroup),
+		waiters: make(map[string]int),
+		results: make(map[string]getResult),
+	}
+}
+
+// Get joins the in-flight batch for key, starting one if none is open, and blocks until
+// that batch's GetMulti call resolves. Several callers asking for the same key in the same
+// window all join the one WaitGroup and share its result.
+func (c *GetCoalescer) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	wg, ok := c.pending[key]
+	if !ok {
+		wg = &sync.WaitGroup{}
+		wg.Add(1)
+		c.pending[key] = wg
+		if c.timer == nil {
+			c.timer = time.AfterFunc(getCoalesceWindow, c.dispatch)
+		}
+	}
+	c.waiters[key]++
+	c.mu.Unlock()
+
+	wg.Wait()
+
+	c.mu.Lock()
+	res := c.results[key]
+	c.waiters[key]--
+	if c.waiters[key] <= 0 {
+		delete(c.waiters, key)
+		delete(c.results, key)
+	}
+	c.mu.Unlock()
+
+	return res.value, res.err
+}
+
+// dispatch fires the batched GetMulti call for every key that joined this window and wakes
+// up all of their waiting Get calls. It runs on its own context, not any one caller's: a
+// batch serves every key that joined the window, so no single caller's cancellation should
+// be able to fail the others' lookups.
+func (c *GetCoalescer) dispatch() {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[string]*sync.WaitGroup)
+	c.timer = nil
+	c.mu.Unlock()
+
+	keys := make([]string, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dispatchTimeout)
+	defer cancel()
+	values, err := c.backend.GetMulti(ctx, keys)
+
+	c.mu.Lock()
+	for _, key := range keys {
+		if err != nil {
+			c.results[key] = getResult{err: err}
+			continue
+		}
+		val, ok := values[key]
+		if !ok {
+			// GetMulti simply omits keys Ignite doesn't have, the same outcome Get reports
+			// as KEY_NOT_FOUND, so a coalesced lookup for a missing key matches the
+			// non-coalesced path instead of resolving to a false ("", nil).
+			c.results[key] = getResult{err: utils.NewPBCError(utils.KEY_NOT_FOUND)}
+			continue
+		}
+		c.results[key] = getResult{value: val}
+	}
+	c.mu.Unlock()
+
+	for _, wg := range pending {
+		wg.Done()
+	}
+}
@@ -0,0 +1,108 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/prebid/prebid-cache/backends"
+	"github.com/prebid/prebid-cache/utils"
+)
+
+// fakeBatchBackend is a backends.BatchBackend whose GetMulti answers out of a fixed map,
+// omitting whatever keys aren't in it, mirroring Ignite's "getall" behavior.
+type fakeBatchBackend struct {
+	values map[string]string
+	err    error
+
+	mu    sync.Mutex
+	calls [][]string
+}
+
+func (b *fakeBatchBackend) GetMulti(ctx context.Context, keys []string) (map[string]string, error) {
+	b.mu.Lock()
+	b.calls = append(b.calls, append([]string(nil), keys...))
+	b.mu.Unlock()
+
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.values, nil
+}
+
+func (b *fakeBatchBackend) PutMulti(ctx context.Context, entries []backends.PutRequest) ([]error, error) {
+	return nil, nil
+}
+
+func TestGetCoalescerReturnsKeyNotFoundForMissingKey(t *testing.T) {
+	backend := &fakeBatchBackend{values: map[string]string{"present": "value"}}
+	coalescer := NewGetCoalescer(backend)
+
+	value, err := coalescer.Get(context.Background(), "missing")
+	if value != "" {
+		t.Fatalf("expected an empty value for a missing key, got %q", value)
+	}
+	pbcErr, ok := err.(*utils.PBCError)
+	if !ok || pbcErr.StatusCode != utils.KEY_NOT_FOUND {
+		t.Fatalf("expected KEY_NOT_FOUND, got %v", err)
+	}
+}
+
+func TestGetCoalescerReturnsValueForPresentKey(t *testing.T) {
+	backend := &fakeBatchBackend{values: map[string]string{"present": "value"}}
+	coalescer := NewGetCoalescer(backend)
+
+	value, err := coalescer.Get(context.Background(), "present")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if value != "value" {
+		t.Fatalf("expected %q, got %q", "value", value)
+	}
+}
+
+func TestGetCoalescerAppliesBatchLevelErrorToEveryJoiner(t *testing.T) {
+	backend := &fakeBatchBackend{err: errors.New("ignite unreachable")}
+	coalescer := NewGetCoalescer(backend)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, key := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			_, errs[i] = coalescer.Get(context.Background(), key)
+		}(i, key)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			t.Fatalf("joiner %d: expected the batch-level error to apply, got nil", i)
+		}
+	}
+}
+
+func TestGetCoalescerMergesConcurrentRequestsIntoOneBatch(t *testing.T) {
+	backend := &fakeBatchBackend{values: map[string]string{"a": "1", "b": "2"}}
+	coalescer := NewGetCoalescer(backend)
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			if _, err := coalescer.Get(context.Background(), key); err != nil {
+				t.Errorf("unexpected error for key %q: %s", key, err.Error())
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if len(backend.calls) != 1 {
+		t.Fatalf("expected concurrent Gets within the coalesce window to produce one GetMulti call, got %d", len(backend.calls))
+	}
+}
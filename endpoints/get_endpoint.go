@@ -0,0 +1,55 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prebid/prebid-cache/backends"
+	"github.com/prebid/prebid-cache/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// getResponse is the body returned by a successful GET.
+type getResponse struct {
+	Value string `json:"value"`
+}
+
+// NewGetEndpoint returns the handler for single-key lookups. When backend implements
+// backends.BatchBackend, concurrent lookups are coalesced through a GetCoalescer so a burst
+// of single-key requests becomes one batched GetMulti call instead of one round-trip each;
+// otherwise every request calls backend.Get directly.
+func NewGetEndpoint(backend backends.Backend) http.HandlerFunc {
+	var coalescer *GetCoalescer
+	if batchBackend, ok := backend.(backends.BatchBackend); ok {
+		coalescer = NewGetCoalescer(batchBackend)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("uuid")
+		if key == "" {
+			http.Error(w, "Missing required parameter uuid", http.StatusBadRequest)
+			return
+		}
+
+		var value string
+		var err error
+		if coalescer != nil {
+			value, err = coalescer.Get(r.Context(), key)
+		} else {
+			value, err = backend.Get(r.Context(), key)
+		}
+
+		if err != nil {
+			status := http.StatusInternalServerError
+			if pbcErr, ok := err.(*utils.PBCError); ok && pbcErr.StatusCode == utils.KEY_NOT_FOUND {
+				status = http.StatusNotFound
+			}
+			log.Debugf("GET /cache uuid=%s failed: %s", key, err.Error())
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(getResponse{Value: value})
+	}
+}
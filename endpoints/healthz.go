@@ -0,0 +1,12 @@
+package endpoints
+
+import "net/http"
+
+// NewHealthzEndpoint returns a liveness probe handler: it answers 200 as long as the process
+// is up and serving HTTP at all, regardless of backend health. Use /readyz to check whether
+// the configured backend is actually reachable.
+func NewHealthzEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+}
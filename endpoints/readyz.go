@@ -0,0 +1,46 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/prebid/prebid-cache/backends"
+)
+
+// readinessTimeout bounds how long /readyz waits on the backend's Ping before declaring it
+// unreachable, so a hung backend can't make the probe itself time out.
+const readinessTimeout = 2 * time.Second
+
+// readyzResponse is the body returned by /readyz when the backend isn't reachable.
+type readyzResponse struct {
+	Error string `json:"error"`
+}
+
+// NewReadyzEndpoint returns a readiness probe handler. When backend implements
+// backends.Pinger, it's pinged with a short deadline on every request; an unreachable
+// backend yields a 503 with a JSON error body so orchestrators can drain traffic away from
+// the pod. Backends that don't implement Pinger are assumed always ready.
+func NewReadyzEndpoint(backend backends.Backend) http.HandlerFunc {
+	pinger, pingable := backend.(backends.Pinger)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !pingable {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		if err := pinger.Ping(ctx); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(readyzResponse{Error: err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -3,21 +3,48 @@ package endpoints
 import (
 	"encoding/json"
 	"net/http"
+	"runtime"
 
 	log "github.com/sirupsen/logrus"
 )
 
 const versionEndpointValueNotSet = "not-set"
 
-// NewVersionEndpoint returns the latest git tag as the version and commit hash as the revision from which the binary was built
-func NewVersionEndpoint(version string) http.HandlerFunc {
+// Revision and BuildTime are populated at build time via ldflags, e.g.:
+//
+//	go build -ldflags "-X github.com/prebid/prebid-cache/endpoints.Revision=$(git rev-parse HEAD) \
+//	  -X github.com/prebid/prebid-cache/endpoints.BuildTime=$(date -u +%FT%TZ)"
+var (
+	Revision  = versionEndpointValueNotSet
+	BuildTime = versionEndpointValueNotSet
+)
+
+// versionResponse is the body returned by the /version endpoint.
+type versionResponse struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+	Backend   string `json:"backend"`
+}
+
+// NewVersionEndpoint returns the latest git tag as the version, the commit hash and build
+// timestamp the binary was built from, the Go runtime it was built with, and the backend
+// type it's configured to use.
+func NewVersionEndpoint(version string, backendType string) http.HandlerFunc {
 	if version == "" {
 		version = versionEndpointValueNotSet
 	}
-	response, err := json.Marshal(struct {
-		Version string `json:"version"`
-	}{
-		Version: version,
+	if backendType == "" {
+		backendType = versionEndpointValueNotSet
+	}
+
+	response, err := json.Marshal(versionResponse{
+		Version:   version,
+		Revision:  Revision,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Backend:   backendType,
 	})
 	if err != nil {
 		log.Fatalf("error creating /version endpoint response: %v", err)